@@ -0,0 +1,68 @@
+package kzg
+
+import "math/bits"
+
+// IsPowerOfTwo reports whether n is a non-zero power of two.
+func IsPowerOfTwo(n uint64) bool {
+	return n != 0 && n&(n-1) == 0
+}
+
+// ReverseBits reverses the bits of n that index into a domain of the given
+// order (which must itself be a power of two), e.g. ReverseBits(1, 8) == 4.
+func ReverseBits(n, order uint64) uint64 {
+	if !IsPowerOfTwo(order) {
+		panic("kzg: ReverseBits order must be a power of two")
+	}
+	return bits.Reverse64(n) >> (65 - bits.Len64(order))
+}
+
+// NewKZGSettingsBitReversed is like NewKZGSettings, but stores the expanded
+// roots of unity already permuted into bit-reversal order. This only
+// changes how the FFT domain itself is indexed: the monomial-basis SRS
+// (secretG1/secretG2) is left untouched, since secretG1[i] must stay the
+// commitment to s^i for CommitToPoly and the single/blob proof APIs to
+// remain correct.
+//
+// This is deliberately a standalone, low-level utility rather than a
+// migration of the proof APIs: CheckProofMulti and FK20MultiDAOptimized
+// still expect and produce natural-order data, and a KZGSettings built here
+// must not be handed to them (or to ComputeProof/VerifyProof/the blob
+// APIs) without routing every input and output through FFTBitReversed
+// first, since those functions index evaluations against
+// ks.expandedRootsOfUnity directly. Moving CheckProofMulti's DAS sample
+// loop onto this domain natively is out of scope for this change; use
+// FFTBitReversed directly where a bit-reversed-domain FFT is needed in the
+// meantime.
+func NewKZGSettingsBitReversed(fs *FFTSettings, s1 []G1, s2 []G2) *KZGSettings {
+	ks := NewKZGSettings(fs, s1, s2)
+
+	reordered := make([]Big, len(ks.expandedRootsOfUnity))
+	copy(reordered, ks.expandedRootsOfUnity)
+	reverseBitOrderBig(reordered)
+	ks.expandedRootsOfUnity = reordered
+
+	return ks
+}
+
+// FFTBitReversed is the bit-reversed-domain counterpart of KZGSettings.FFT:
+// forward transforms (inv == false) take natural-order values and return
+// them in bit-reversal-permuted order; inverse transforms (inv == true) take
+// bit-reversal-permuted values and return natural-order coefficients. Either
+// way, the caller never has to call reverseBitOrderBig itself.
+func (ks *KZGSettings) FFTBitReversed(vals []Big, inv bool) ([]Big, error) {
+	input := make([]Big, len(vals))
+	copy(input, vals)
+	if inv {
+		reverseBitOrderBig(input)
+	}
+
+	out, err := ks.FFT(input, inv)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inv {
+		reverseBitOrderBig(out)
+	}
+	return out, nil
+}
@@ -0,0 +1,48 @@
+package kzg
+
+import "testing"
+
+func TestIsPowerOfTwo(t *testing.T) {
+	cases := map[uint64]bool{
+		0:   false,
+		1:   true,
+		2:   true,
+		3:   false,
+		4:   true,
+		255: false,
+		256: true,
+	}
+	for n, want := range cases {
+		if got := IsPowerOfTwo(n); got != want {
+			t.Errorf("IsPowerOfTwo(%d) = %v, want %v", n, got, want)
+		}
+	}
+}
+
+func TestReverseBits(t *testing.T) {
+	cases := []struct {
+		n, order, want uint64
+	}{
+		{0, 8, 0},
+		{1, 8, 4},
+		{2, 8, 2},
+		{3, 8, 6},
+		{1, 4, 2},
+		{3, 4, 3},
+	}
+	for _, c := range cases {
+		if got := ReverseBits(c.n, c.order); got != c.want {
+			t.Errorf("ReverseBits(%d, %d) = %d, want %d", c.n, c.order, got, c.want)
+		}
+	}
+}
+
+func TestReverseBitsInvolution(t *testing.T) {
+	const order = 16
+	for i := uint64(0); i < order; i++ {
+		j := ReverseBits(i, order)
+		if back := ReverseBits(j, order); back != i {
+			t.Errorf("ReverseBits is not an involution for %d: got %d, then %d", i, j, back)
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package kzg
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/big"
+)
+
+// frOrder is the order r of the BLS12-381 scalar field Fr.
+var frOrder, _ = new(big.Int).SetString("52435875175126190479447740508185965837690552500527637822603658699938581184513", 10)
+
+// FieldElementsPerBlob is the number of field elements encoded in a single
+// EIP-4844 blob. A blob is FieldElementsPerBlob*32 little-endian bytes, each
+// 32-byte chunk being a BigNumFrom32-decodable field element.
+const FieldElementsPerBlob = 4096
+
+// fiatShamirProtocolDomain separates the challenge hash used to derive the
+// evaluation point for a blob proof from any other use of SHA-256 in the
+// protocol.
+const fiatShamirProtocolDomain = "FSBLOBVERIFY_V1_"
+
+// blobToEvaluations decodes a blob into its FieldElementsPerBlob evaluations,
+// in the same natural (not canonically bit-reversed) domain order that
+// ComputeProof expects - see single_proof.go for why that matters.
+func blobToEvaluations(blob []byte) ([]Big, error) {
+	if len(blob) != FieldElementsPerBlob*32 {
+		return nil, errors.New("blob has invalid size")
+	}
+	evaluations := make([]Big, FieldElementsPerBlob)
+	var chunk [32]byte
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		copy(chunk[:], blob[i*32:(i+1)*32])
+		BigNumFrom32(&evaluations[i], chunk)
+	}
+	return evaluations, nil
+}
+
+// computeChallenge derives the Fiat-Shamir evaluation point z for a blob
+// proof from the blob contents and its commitment: z is the SHA-256 hash of
+// a domain separator, FieldElementsPerBlob as a little-endian uint64, the
+// blob bytes and the compressed commitment, reduced modulo the scalar field
+// order.
+func computeChallenge(blob []byte, commitment *G1) *Big {
+	h := sha256.New()
+	h.Write([]byte(fiatShamirProtocolDomain))
+	var countBytes [8]byte
+	binary.LittleEndian.PutUint64(countBytes[:], FieldElementsPerBlob)
+	h.Write(countBytes[:])
+	h.Write(blob)
+	h.Write(CompressG1(commitment))
+	digest := h.Sum(nil)
+
+	// A raw SHA-256 digest is not generally a canonical field element
+	// (unlike the 31-byte payloads elsewhere in this package, its top
+	// bits are uncontrolled), so it must be reduced modulo the scalar
+	// field order explicitly rather than passed through BigNumFrom32.
+	var reduced big.Int
+	reduced.SetBytes(digest)
+	reduced.Mod(&reduced, frOrder)
+
+	var z Big
+	bigNum(&z, reduced.String())
+	return &z
+}
+
+// BlobToKZGCommitment computes the KZG commitment to the polynomial
+// represented (in evaluation form) by a blob.
+func (ks *KZGSettings) BlobToKZGCommitment(blob []byte) (*G1, error) {
+	evaluations, err := blobToEvaluations(blob)
+	if err != nil {
+		return nil, err
+	}
+	// evaluations is in evaluation form; ks.secretG1 is the
+	// monomial-basis SRS, so it must be committed in coefficient form.
+	coeffs, err := ks.FFT(evaluations, true)
+	if err != nil {
+		return nil, err
+	}
+	return ks.CommitToPoly(coeffs), nil
+}
+
+// ComputeBlobKZGProof computes the KZG proof that is used to verify the
+// correctness of a blob's commitment, opening the polynomial at a
+// Fiat-Shamir-derived point rather than one chosen by the caller.
+func (ks *KZGSettings) ComputeBlobKZGProof(blob []byte, commitment *G1) (*G1, error) {
+	evaluations, err := blobToEvaluations(blob)
+	if err != nil {
+		return nil, err
+	}
+	z := computeChallenge(blob, commitment)
+	return ks.ComputeProof(evaluations, z)
+}
+
+// VerifyBlobKZGProof checks that proof is a valid KZG opening of commitment
+// to blob, at the Fiat-Shamir point derived from blob and commitment.
+func (ks *KZGSettings) VerifyBlobKZGProof(blob []byte, commitment *G1, proof *G1) bool {
+	evaluations, err := blobToEvaluations(blob)
+	if err != nil {
+		return false
+	}
+	z := computeChallenge(blob, commitment)
+	y, err := ks.evalPolyInEvalForm(evaluations, z)
+	if err != nil {
+		return false
+	}
+	return ks.VerifyProof(commitment, z, y, proof)
+}
+
+// VerifyBlobKZGProofBatch verifies a batch of blob/commitment/proof triples
+// at once. Instead of one pairing check per triple, it takes a random
+// linear combination of the batch so that only two pairings
+// are required in total, regardless of batch size.
+func (ks *KZGSettings) VerifyBlobKZGProofBatch(blobs [][]byte, commitments []*G1, proofs []*G1) (bool, error) {
+	if len(blobs) != len(commitments) || len(blobs) != len(proofs) {
+		return false, errors.New("blobs, commitments and proofs must have the same length")
+	}
+	if len(blobs) == 0 {
+		return true, nil
+	}
+
+	n := len(blobs)
+	zs := make([]Big, n)
+	ys := make([]Big, n)
+	for i := 0; i < n; i++ {
+		evaluations, err := blobToEvaluations(blobs[i])
+		if err != nil {
+			return false, err
+		}
+		z := computeChallenge(blobs[i], commitments[i])
+		y, err := ks.evalPolyInEvalForm(evaluations, z)
+		if err != nil {
+			return false, err
+		}
+		CopyBigNum(&zs[i], z)
+		CopyBigNum(&ys[i], y)
+	}
+
+	// random weights for the linear combination, one per (blob, commitment, proof)
+	rs := make([]Big, n)
+	for i := range rs {
+		CopyBigNum(&rs[i], randomBig())
+	}
+
+	// LHS: sum_i r_i * (commitment_i - [y_i]G1)
+	lhsTerms := make([]G1, n)
+	for i := 0; i < n; i++ {
+		var yG1 G1
+		mulG1(&yG1, &GenG1, &ys[i])
+		subG1(&lhsTerms[i], commitments[i], &yG1)
+	}
+	lhs := LinCombG1(lhsTerms, rs)
+
+	// RHS: sum_i r_i * proof_i, paired against [s]G2. The [z_i]G2 part of
+	// each single check is moved to the G2 side instead: sum_i (r_i * z_i) *
+	// proof_i is added to the LHS sum. Both are MSMs of the proofs, one
+	// against weights r_i and one against r_i*z_i.
+	proofPoints := make([]G1, n)
+	weightedProofScalars := make([]Big, n)
+	for i := 0; i < n; i++ {
+		proofPoints[i] = *proofs[i]
+		mulModBig(&weightedProofScalars[i], &rs[i], &zs[i])
+	}
+	proofSum := LinCombG1(proofPoints, rs)
+	weightedProofSum := LinCombG1(proofPoints, weightedProofScalars)
+
+	var rhsG1 G1
+	addG1(&rhsG1, lhs, weightedProofSum)
+
+	return PairingsVerify(&rhsG1, &GenG2, proofSum, &ks.secretG2[1]), nil
+}
@@ -0,0 +1,88 @@
+// +build !bignum_pure,!bignum_hol256
+
+package kzg
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randomBlob(seed int64) []byte {
+	blob := make([]byte, FieldElementsPerBlob*32)
+	rng := rand.New(rand.NewSource(seed))
+	rng.Read(blob)
+	// clear the top byte of every 32-byte (little-endian) chunk so each
+	// field element decodes to a canonical value, the same precaution
+	// integrationTestSetup takes around BigNumFrom32.
+	for i := 0; i < FieldElementsPerBlob; i++ {
+		blob[i*32+31] = 0
+	}
+	return blob
+}
+
+func blobTestSettings() *KZGSettings {
+	fs := NewFFTSettings(scaleOf(FieldElementsPerBlob))
+	s1, s2 := generateSetup("1927409816240961209460912649124", FieldElementsPerBlob)
+	return NewKZGSettings(fs, s1, s2)
+}
+
+func TestBlobCommitProveVerify(t *testing.T) {
+	ks := blobTestSettings()
+	blob := randomBlob(99)
+
+	commitment, err := ks.BlobToKZGCommitment(blob)
+	if err != nil {
+		t.Fatalf("BlobToKZGCommitment failed: %v", err)
+	}
+	proof, err := ks.ComputeBlobKZGProof(blob, commitment)
+	if err != nil {
+		t.Fatalf("ComputeBlobKZGProof failed: %v", err)
+	}
+	if !ks.VerifyBlobKZGProof(blob, commitment, proof) {
+		t.Fatal("failed to verify a correctly computed blob proof")
+	}
+
+	otherBlob := randomBlob(100)
+	if ks.VerifyBlobKZGProof(otherBlob, commitment, proof) {
+		t.Fatal("expected verification to fail for a mismatched blob")
+	}
+}
+
+func TestVerifyBlobKZGProofBatch(t *testing.T) {
+	ks := blobTestSettings()
+	const batchSize = 4
+
+	blobs := make([][]byte, batchSize)
+	commitments := make([]*G1, batchSize)
+	proofs := make([]*G1, batchSize)
+	for i := range blobs {
+		blobs[i] = randomBlob(int64(i) + 1)
+		commitment, err := ks.BlobToKZGCommitment(blobs[i])
+		if err != nil {
+			t.Fatalf("BlobToKZGCommitment failed: %v", err)
+		}
+		proof, err := ks.ComputeBlobKZGProof(blobs[i], commitment)
+		if err != nil {
+			t.Fatalf("ComputeBlobKZGProof failed: %v", err)
+		}
+		commitments[i] = commitment
+		proofs[i] = proof
+	}
+
+	ok, err := ks.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	if err != nil {
+		t.Fatalf("VerifyBlobKZGProofBatch failed: %v", err)
+	}
+	if !ok {
+		t.Fatal("expected batch verification to succeed")
+	}
+
+	proofs[1] = proofs[0]
+	ok, err = ks.VerifyBlobKZGProofBatch(blobs, commitments, proofs)
+	if err != nil {
+		t.Fatalf("VerifyBlobKZGProofBatch failed: %v", err)
+	}
+	if ok {
+		t.Fatal("expected batch verification to fail for a swapped proof")
+	}
+}
@@ -0,0 +1,328 @@
+// Package ceremony parses the transcript of the Ethereum KZG Summoning
+// Ceremony and turns it into the monomial-form G1 and G2 SRS that
+// kzg.NewKZGSettings expects, without requiring a trusted dealer.
+package ceremony
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+
+	kzg "github.com/protolambda/go-kzg"
+)
+
+// subCeremonySizes are the widths of the four sub-ceremonies that make up
+// the full transcript, from the smallest to the largest DAS extension
+// factor the ceremony was run for.
+var subCeremonySizes = [4]int{4096, 8192, 16384, 32768}
+
+// subCeremony mirrors one entry of the transcript's "transcripts" array.
+type subCeremony struct {
+	NumG1Powers int      `json:"numG1Powers"`
+	NumG2Powers int      `json:"numG2Powers"`
+	G1Lagrange  []string `json:"g1Lagrange"`
+	G1Powers    []string `json:"g1Powers"`
+	G2Powers    []string `json:"g2Powers"`
+}
+
+// SRS is the structured reference string extracted from one sub-ceremony,
+// in the forms needed to construct KZG settings and to verify the
+// ceremony's own internal consistency.
+type SRS struct {
+	// S1Monomial and S2Monomial are the monomial-basis powers of the
+	// secret, [s^i]G1 and [s^i]G2. These are what kzg.NewKZGSettings takes
+	// as its s1/s2 arguments: KZGSettings.CommitToPoly, and every proof
+	// built on top of it (single_proof.go, blob.go), commit polynomials in
+	// coefficient form against a monomial-basis secretG1, so handing it
+	// S1Lagrange instead would silently produce wrong commitments.
+	S1Monomial []kzg.G1
+	S2Monomial []kzg.G2
+	// S1Lagrange is the Lagrange-basis G1 SRS the ceremony transcript also
+	// publishes. Apart from the subgroup check in Verify, this package does
+	// not use it; it is kept on SRS
+	// so callers that want the Lagrange form (e.g. to commit evaluation-form
+	// data directly, without an IFFT) don't have to re-parse the transcript.
+	S1Lagrange []kzg.G1
+}
+
+// Parse streams through r looking for the sub-ceremony of the given width
+// (one of 4096, 8192, 16384 or 32768) and decodes it into an SRS. Because it
+// walks the "transcripts" array token by token, a transcript file larger
+// than available memory never needs to be held in full: sub-ceremonies
+// other than the requested one are skipped without being unmarshaled.
+func Parse(r io.Reader, width int) (*SRS, error) {
+	valid := false
+	for _, w := range subCeremonySizes {
+		if w == width {
+			valid = true
+		}
+	}
+	if !valid {
+		return nil, fmt.Errorf("ceremony: %d is not a valid sub-ceremony width", width)
+	}
+
+	sc, err := findSubCeremony(r, width)
+	if err != nil {
+		return nil, err
+	}
+	return sc.toSRS()
+}
+
+func findSubCeremony(r io.Reader, width int) (*subCeremony, error) {
+	dec := json.NewDecoder(bufio.NewReaderSize(r, 1<<20))
+
+	if err := seekToKey(dec, "transcripts"); err != nil {
+		return nil, err
+	}
+	if err := expectDelim(dec, '['); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		sc, matched, err := decodeSubCeremony(dec, width)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			return sc, nil
+		}
+	}
+	return nil, fmt.Errorf("ceremony: no sub-ceremony of width %d in transcript", width)
+}
+
+// decodeSubCeremony reads one object of the "transcripts" array. It assumes
+// numG1Powers appears before the point arrays, which is true of every
+// ceremony transcript in the wild: once it has seen numG1Powers and found it
+// doesn't match width, the (potentially huge) g1Lagrange/g1Powers/g2Powers
+// arrays are walked token by token and discarded via skipValue rather than
+// unmarshaled into string slices, so a non-matching sub-ceremony never has
+// to be held in memory in full.
+func decodeSubCeremony(dec *json.Decoder, width int) (sc *subCeremony, matched bool, err error) {
+	if err := expectDelim(dec, '{'); err != nil {
+		return nil, false, err
+	}
+
+	out := &subCeremony{}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, false, err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return nil, false, errors.New("ceremony: expected an object key")
+		}
+
+		switch key {
+		case "numG1Powers":
+			if err := dec.Decode(&out.NumG1Powers); err != nil {
+				return nil, false, err
+			}
+			matched = out.NumG1Powers == width
+		case "numG2Powers":
+			if err := dec.Decode(&out.NumG2Powers); err != nil {
+				return nil, false, err
+			}
+		case "g1Lagrange":
+			if err := decodeOrSkip(dec, matched, &out.G1Lagrange); err != nil {
+				return nil, false, err
+			}
+		case "g1Powers":
+			if err := decodeOrSkip(dec, matched, &out.G1Powers); err != nil {
+				return nil, false, err
+			}
+		case "g2Powers":
+			if err := decodeOrSkip(dec, matched, &out.G2Powers); err != nil {
+				return nil, false, err
+			}
+		default:
+			if err := skipValue(dec); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+	if _, err := dec.Token(); err != nil { // consume the closing '}'
+		return nil, false, err
+	}
+
+	return out, matched, nil
+}
+
+// decodeOrSkip unmarshals the decoder's current value into dst when keep is
+// true, and otherwise discards it via skipValue without allocating a slice
+// for it.
+func decodeOrSkip(dec *json.Decoder, keep bool, dst *[]string) error {
+	if keep {
+		return dec.Decode(dst)
+	}
+	return skipValue(dec)
+}
+
+// skipValue advances dec past one JSON value (scalar, object or array)
+// without unmarshaling it into any Go representation, so that a large array
+// can be discarded in O(1) additional memory.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	delim, ok := tok.(json.Delim)
+	if !ok || (delim != '{' && delim != '[') {
+		return nil
+	}
+	depth := 1
+	for depth > 0 {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		if d, ok := tok.(json.Delim); ok {
+			switch d {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+	}
+	return nil
+}
+
+// seekToKey advances dec past the opening '{' of the current object and
+// every key/value pair that doesn't match key, leaving dec positioned right
+// before key's value.
+func seekToKey(dec *json.Decoder, key string) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, ok := tok.(string)
+		if !ok {
+			return errors.New("ceremony: expected an object key")
+		}
+		if name == key {
+			return nil
+		}
+		var discard json.RawMessage
+		if err := dec.Decode(&discard); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("ceremony: key %q not found", key)
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	d, ok := tok.(json.Delim)
+	if !ok || d != want {
+		return errors.New("ceremony: malformed transcript")
+	}
+	return nil
+}
+
+func (sc *subCeremony) toSRS() (*SRS, error) {
+	srs := &SRS{
+		S1Lagrange: make([]kzg.G1, len(sc.G1Lagrange)),
+		S1Monomial: make([]kzg.G1, len(sc.G1Powers)),
+		S2Monomial: make([]kzg.G2, len(sc.G2Powers)),
+	}
+	for i, h := range sc.G1Lagrange {
+		if err := decodeG1(&srs.S1Lagrange[i], h); err != nil {
+			return nil, fmt.Errorf("ceremony: g1Lagrange[%d]: %w", i, err)
+		}
+	}
+	for i, h := range sc.G1Powers {
+		if err := decodeG1(&srs.S1Monomial[i], h); err != nil {
+			return nil, fmt.Errorf("ceremony: g1Powers[%d]: %w", i, err)
+		}
+	}
+	for i, h := range sc.G2Powers {
+		if err := decodeG2(&srs.S2Monomial[i], h); err != nil {
+			return nil, fmt.Errorf("ceremony: g2Powers[%d]: %w", i, err)
+		}
+	}
+	return srs, nil
+}
+
+func decodeG1(dst *kzg.G1, hexStr string) error {
+	b, err := hex.DecodeString(trim0x(hexStr))
+	if err != nil {
+		return err
+	}
+	return kzg.DeserializeG1(dst, b)
+}
+
+func decodeG2(dst *kzg.G2, hexStr string) error {
+	b, err := hex.DecodeString(trim0x(hexStr))
+	if err != nil {
+		return err
+	}
+	return kzg.DeserializeG2(dst, b)
+}
+
+func trim0x(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+	return s
+}
+
+// sampleSize caps the number of consecutive-power pairing checks run by
+// Verify: checking every power of a >1 GiB transcript would itself take
+// longer than the rest of start-up combined, so a random subset stands in.
+const sampleSize = 32
+
+// Verify checks that srs is internally consistent: every point lies in the
+// correct subgroup, the toxic secret wasn't degenerate (s*G1 and s*G2 are
+// non-identity), e(s1[1], G2) == e(G1, s2[1]) ties the G1 and G2 powers
+// together, and a random subset of consecutive monomial powers satisfy
+// e(s1[i+1], G2) == e(s1[i], s2[1]).
+func (srs *SRS) Verify() error {
+	for i := range srs.S1Lagrange {
+		if !kzg.InG1(&srs.S1Lagrange[i]) {
+			return fmt.Errorf("ceremony: s1Lagrange[%d] is not in the G1 subgroup", i)
+		}
+	}
+	for i := range srs.S1Monomial {
+		if !kzg.InG1(&srs.S1Monomial[i]) {
+			return fmt.Errorf("ceremony: s1Monomial[%d] is not in the G1 subgroup", i)
+		}
+	}
+	for i := range srs.S2Monomial {
+		if !kzg.InG2(&srs.S2Monomial[i]) {
+			return fmt.Errorf("ceremony: s2Monomial[%d] is not in the G2 subgroup", i)
+		}
+	}
+
+	if len(srs.S1Monomial) < 2 || len(srs.S2Monomial) < 2 {
+		return errors.New("ceremony: need at least two monomial powers to verify consistency")
+	}
+	if kzg.IsZeroG1(&srs.S1Monomial[1]) || kzg.IsZeroG2(&srs.S2Monomial[1]) {
+		return errors.New("ceremony: toxic waste secret appears to be zero")
+	}
+	if !kzg.PairingsVerify(&srs.S1Monomial[1], &kzg.GenG2, &kzg.GenG1, &srs.S2Monomial[1]) {
+		return errors.New("ceremony: e(s1Monomial[1], G2) != e(G1, s2Monomial[1])")
+	}
+
+	n := len(srs.S1Monomial) - 1
+	checks := sampleSize
+	if checks > n {
+		checks = n
+	}
+	for _, i := range rand.Perm(n)[:checks] {
+		if !kzg.PairingsVerify(&srs.S1Monomial[i+1], &kzg.GenG2, &srs.S1Monomial[i], &srs.S2Monomial[1]) {
+			return fmt.Errorf("ceremony: e(s1Monomial[%d], G2) != e(s1Monomial[%d], s2Monomial[1])", i+1, i)
+		}
+	}
+	return nil
+}
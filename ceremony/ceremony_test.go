@@ -0,0 +1,70 @@
+package ceremony
+
+import (
+	"strings"
+	"testing"
+)
+
+const fixture = `{
+  "transcripts": [
+    {"numG1Powers": 4096, "numG2Powers": 65, "g1Lagrange": ["0x01"], "g1Powers": ["0x01"], "g2Powers": ["0x02"]},
+    {"numG1Powers": 8192, "numG2Powers": 65, "g1Lagrange": ["0x03"], "g1Powers": ["0x03"], "g2Powers": ["0x04"]}
+  ]
+}`
+
+// fixtureWithGarbage is like fixture, but the non-matching (4096) entry's
+// point arrays contain values that are not strings at all (and one string
+// that is not valid hex). If findSubCeremony ever tried to unmarshal them
+// into string slices it would fail; the only way this fixture
+// parses cleanly for width 8192 is if that entry's arrays are genuinely
+// skipped rather than unmarshaled.
+const fixtureWithGarbage = `{
+  "transcripts": [
+    {"numG1Powers": 4096, "numG2Powers": 65, "g1Lagrange": ["not-hex!!"], "g1Powers": [123], "g2Powers": [{"bad": true}]},
+    {"numG1Powers": 8192, "numG2Powers": 65, "g1Lagrange": ["0x03"], "g1Powers": ["0x03"], "g2Powers": ["0x04"]}
+  ]
+}`
+
+func TestFindSubCeremonySkipsNonMatching(t *testing.T) {
+	sc, err := findSubCeremony(strings.NewReader(fixture), 8192)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sc.NumG1Powers != 8192 {
+		t.Fatalf("got sub-ceremony of width %d, want 8192", sc.NumG1Powers)
+	}
+	if len(sc.G1Lagrange) != 1 || sc.G1Lagrange[0] != "0x03" {
+		t.Fatalf("unexpected g1Lagrange: %v", sc.G1Lagrange)
+	}
+}
+
+func TestFindSubCeremonyMissingWidth(t *testing.T) {
+	if _, err := findSubCeremony(strings.NewReader(fixture), 32768); err == nil {
+		t.Fatal("expected an error for a width absent from the transcript")
+	}
+}
+
+func TestTrim0x(t *testing.T) {
+	cases := map[string]string{
+		"0x1234": "1234",
+		"0X1234": "1234",
+		"1234":   "1234",
+		"":       "",
+		"0x":     "",
+	}
+	for in, want := range cases {
+		if got := trim0x(in); got != want {
+			t.Errorf("trim0x(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFindSubCeremonySkipsWithoutDecoding(t *testing.T) {
+	sc, err := findSubCeremony(strings.NewReader(fixtureWithGarbage), 8192)
+	if err != nil {
+		t.Fatalf("expected the malformed 4096 entry to be skipped untouched, got: %v", err)
+	}
+	if sc.NumG1Powers != 8192 {
+		t.Fatalf("got sub-ceremony of width %d, want 8192", sc.NumG1Powers)
+	}
+}
@@ -0,0 +1,108 @@
+package kzg
+
+// FK20MultiSettings groups a KZGSettings with the Toeplitz precomputation
+// and the ProofBackend needed to batch-produce FK20 opening proofs for
+// cosets of a fixed width, using the Feist-Khovratovich technique.
+type FK20MultiSettings struct {
+	*KZGSettings
+	chunkLen uint64
+	// xExtFFTPrecomputes holds, one entry per coset offset, the
+	// frequency-domain form of that offset's zero-padded column of the
+	// SRS-derived Toeplitz matrix (length k2 = 2*maxWidth/chunkLen). It is
+	// computed once at construction time through the configured
+	// ProofBackend, and reused by every subsequent call to
+	// FK20MultiDAOptimized.
+	xExtFFTPrecomputes [][]G1
+	backend            ProofBackend
+}
+
+// NewFK20MultiSettings builds FK20MultiSettings for proofs over cosets of
+// width chunkLen in a domain of size n, using the default CPU ProofBackend.
+func NewFK20MultiSettings(ks *KZGSettings, n uint64, chunkLen uint64) *FK20MultiSettings {
+	return NewFK20MultiSettingsWithBackend(ks, n, chunkLen, nil)
+}
+
+// buildFK20MultiSettings performs the Toeplitz precomputation shared by both
+// constructors, once a backend has already been resolved. For each coset
+// offset it builds the Toeplitz matrix's defining column from the
+// monomial-basis SRS, zero-pads it from k to k2 = 2k (the standard circulant
+// embedding that lets a single length-k2 FFT stand in for a Toeplitz
+// matrix-vector product), and transforms it with the backend.
+func buildFK20MultiSettings(ks *KZGSettings, n uint64, chunkLen uint64, backend ProofBackend) *FK20MultiSettings {
+	if !IsPowerOfTwo(n) || !IsPowerOfTwo(chunkLen) {
+		panic("kzg: FK20 settings require power-of-two n and chunkLen")
+	}
+	k := n / chunkLen
+	k2 := 2 * k
+
+	precomputes := make([][]G1, chunkLen)
+	for offset := uint64(0); offset < chunkLen; offset++ {
+		column := make([]G1, k2)
+		for i := uint64(0); i < k-1; i++ {
+			column[i] = ks.secretG1[n-1-offset-i*chunkLen]
+		}
+		column[k-1] = ZERO_G1
+		for i := k; i < k2; i++ {
+			column[i] = ZERO_G1
+		}
+		precomputes[offset] = backend.FFTG1(column, false)
+	}
+
+	return &FK20MultiSettings{
+		KZGSettings:        ks,
+		chunkLen:           chunkLen,
+		xExtFFTPrecomputes: precomputes,
+		backend:            backend,
+	}
+}
+
+// FK20MultiDAOptimized computes one opening proof per coset of width
+// fk.chunkLen, i.e. k2 = 2*fk.maxWidth/fk.chunkLen proofs in total (the
+// factor of 2 comes from polynomial being in the already-extended domain).
+// For each offset it builds that offset's zero-padded, FFT'd coefficient
+// vector and combines it elementwise with the offset's precomputed Toeplitz
+// column (ToeplitzPart2, via fk.backend); these per-offset products are
+// summed into a single frequency-domain vector hExtFFT (ToeplitzPart1). An
+// inverse FFTG1 (ToeplitzPart3) turns that sum back into per-coefficient
+// commitments, the top half of which is discarded as an artifact of the
+// circulant embedding, and a final forward FFTG1 produces the k2 proofs.
+func (fk *FK20MultiSettings) FK20MultiDAOptimized(polynomial []Big) []G1 {
+	n := fk.maxWidth
+	k := n / fk.chunkLen
+	k2 := 2 * k
+
+	hExtFFT := make([]G1, k2)
+	for i := range hExtFFT {
+		hExtFFT[i] = ZERO_G1
+	}
+
+	for offset := uint64(0); offset < fk.chunkLen; offset++ {
+		toeplitzCoeffs := make([]Big, k2)
+		for i := uint64(0); i < k; i++ {
+			CopyBigNum(&toeplitzCoeffs[i], &polynomial[offset+i*fk.chunkLen])
+		}
+		// toeplitzCoeffs[k:] stays at its zero value from make(), matching
+		// the zero padding of the precomputed column above.
+
+		coeffsFFT, err := fk.FFT(toeplitzCoeffs, false)
+		if err != nil {
+			panic(err)
+		}
+
+		product := fk.backend.ToeplitzPart2(coeffsFFT, fk.xExtFFTPrecomputes[offset])
+		for j := range hExtFFT {
+			addG1(&hExtFFT[j], &hExtFFT[j], &product[j])
+		}
+	}
+
+	// ToeplitzPart3: inverse FFT back out of the frequency domain. Only the
+	// first k entries correspond to the actual Toeplitz product; the rest
+	// is an artifact of the circulant embedding and must be zeroed before
+	// the final forward transform.
+	h := fk.backend.FFTG1(hExtFFT, true)
+	for i := k; i < k2; i++ {
+		h[i] = ZERO_G1
+	}
+
+	return fk.backend.FFTG1(h, false)
+}
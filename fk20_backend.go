@@ -0,0 +1,197 @@
+package kzg
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ProofBackend abstracts the heavy linear-algebra primitives that FK20
+// multi-proof computation relies on, so that a downstream project can plug
+// in a cgo/CUDA implementation without forking this package.
+type ProofBackend interface {
+	// MSM computes the multi-scalar multiplication sum_i scalars[i]*points[i].
+	MSM(points []G1, scalars []Big) G1
+	// FFTG1 runs a (possibly inverse) FFT over G1 points, over the domain
+	// the backend was constructed with.
+	FFTG1(vals []G1, inv bool) []G1
+	// ToeplitzPart2 computes the elementwise (Hadamard) product, in the
+	// group, of row (one coset offset's FFT'd Toeplitz SRS column) with
+	// coeffsFFT (that offset's FFT'd, zero-padded polynomial
+	// coefficients): result[j] = coeffsFFT[j] * row[j]. Both must have
+	// the same length k2 = 2*n/chunkLen; summing the result across every
+	// offset (and then running ToeplitzPart3, an inverse FFTG1) is the
+	// caller's responsibility.
+	ToeplitzPart2(coeffsFFT []Big, row []G1) []G1
+}
+
+// cpuBackend is the default ProofBackend: it shards MSM work, and the
+// per-row work of ToeplitzPart2, across runtime.NumCPU() goroutines, using a
+// Pippenger-style bucketed approach for each shard's MSM.
+type cpuBackend struct {
+	fs         *FFTSettings
+	shards     int
+	bucketBits int
+}
+
+// NewCPUBackend returns the default CPU ProofBackend for the given domain,
+// sharding work across runtime.NumCPU() goroutines.
+func NewCPUBackend(fs *FFTSettings) ProofBackend {
+	return &cpuBackend{fs: fs, shards: runtime.NumCPU(), bucketBits: 8}
+}
+
+func (b *cpuBackend) MSM(points []G1, scalars []Big) G1 {
+	if len(points) == 0 {
+		return ZERO_G1
+	}
+	shards := b.shards
+	if shards > len(points) {
+		shards = len(points)
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	chunk := (len(points) + shards - 1) / shards
+	partials := make([]G1, shards)
+
+	var wg sync.WaitGroup
+	for s := 0; s < shards; s++ {
+		start := s * chunk
+		end := start + chunk
+		if end > len(points) {
+			end = len(points)
+		}
+		if start >= end {
+			continue
+		}
+		wg.Add(1)
+		go func(s, start, end int) {
+			defer wg.Done()
+			partials[s] = pippengerMSM(points[start:end], scalars[start:end], b.bucketBits)
+		}(s, start, end)
+	}
+	wg.Wait()
+
+	sum := ZERO_G1
+	for i := range partials {
+		addG1(&sum, &sum, &partials[i])
+	}
+	return sum
+}
+
+func (b *cpuBackend) FFTG1(vals []G1, inv bool) []G1 {
+	out, err := b.fs.FFTG1(vals, inv)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// ToeplitzPart2 shards the elementwise group multiplications row[j] *
+// coeffsFFT[j] across goroutines.
+func (b *cpuBackend) ToeplitzPart2(coeffsFFT []Big, row []G1) []G1 {
+	n := len(row)
+	out := make([]G1, n)
+
+	shards := b.shards
+	if shards > n {
+		shards = n
+	}
+	if shards < 1 {
+		shards = 1
+	}
+	chunk := (n + shards - 1) / shards
+
+	var wg sync.WaitGroup
+	for s := 0; s < shards; s++ {
+		start := s * chunk
+		end := start + chunk
+		if end > n {
+			end = n
+		}
+		if start >= end {
+			continue
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			for j := start; j < end; j++ {
+				mulG1(&out[j], &row[j], &coeffsFFT[j])
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return out
+}
+
+// pippengerMSM computes a multi-scalar multiplication by splitting each
+// scalar into windows of bucketBits bits, accumulating points into
+// 2^bucketBits buckets per window, then combining windows via repeated
+// doubling (the standard Pippenger bucket method).
+func pippengerMSM(points []G1, scalars []Big, bucketBits int) G1 {
+	if len(points) == 0 {
+		return ZERO_G1
+	}
+	const scalarBits = 256
+	numBuckets := 1 << uint(bucketBits)
+	numWindows := (scalarBits + bucketBits - 1) / bucketBits
+
+	result := ZERO_G1
+	for w := numWindows - 1; w >= 0; w-- {
+		for i := 0; i < bucketBits; i++ {
+			addG1(&result, &result, &result)
+		}
+
+		buckets := make([]G1, numBuckets)
+		for i := range buckets {
+			buckets[i] = ZERO_G1
+		}
+		for i := range points {
+			digit := scalarWindow(&scalars[i], w, bucketBits)
+			if digit == 0 {
+				continue
+			}
+			addG1(&buckets[digit], &buckets[digit], &points[i])
+		}
+
+		// running-sum trick: sum_{j=1}^{numBuckets-1} j*buckets[j] in O(numBuckets).
+		windowSum := ZERO_G1
+		runningSum := ZERO_G1
+		for j := numBuckets - 1; j > 0; j-- {
+			addG1(&runningSum, &runningSum, &buckets[j])
+			addG1(&windowSum, &windowSum, &runningSum)
+		}
+		addG1(&result, &result, &windowSum)
+	}
+	return result
+}
+
+// scalarWindow extracts the `bits`-wide digit at window index `window`
+// (window 0 = least significant) from the little-endian encoding of b.
+func scalarWindow(b *Big, window, bits int) int {
+	enc := BigNumTo32(b)
+	bitOffset := window * bits
+	v := 0
+	for i := 0; i < bits; i++ {
+		bitPos := bitOffset + i
+		byteIdx := bitPos / 8
+		if byteIdx >= len(enc) {
+			break
+		}
+		if (enc[byteIdx]>>uint(bitPos%8))&1 == 1 {
+			v |= 1 << uint(i)
+		}
+	}
+	return v
+}
+
+// NewFK20MultiSettingsWithBackend builds FK20MultiSettings the same way as
+// NewFK20MultiSettings, but lets the caller choose the ProofBackend that
+// performs the Toeplitz products (ToeplitzPart2) and G1 FFTs, so that a cgo/CUDA implementation can
+// be substituted without forking this package. A nil backend falls back to
+// NewCPUBackend.
+func NewFK20MultiSettingsWithBackend(ks *KZGSettings, n uint64, cosetWidth uint64, backend ProofBackend) *FK20MultiSettings {
+	if backend == nil {
+		backend = NewCPUBackend(ks.FFTSettings)
+	}
+	return buildFK20MultiSettings(ks, n, cosetWidth, backend)
+}
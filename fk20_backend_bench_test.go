@@ -0,0 +1,44 @@
+// +build !bignum_pure,!bignum_hol256
+
+package kzg
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// benchScales are the cosetWidths exercised by BenchmarkFK20Backends, small
+// enough to run in CI but large enough to show the effect of sharding.
+var benchScales = []uint64{16, 32, 64, 128}
+
+// BenchmarkFK20Backends reports proofs/sec for each ProofBackend across a
+// range of cosetWidths, so that a change to the sharding strategy (or a new
+// backend) can be compared against the current default.
+func BenchmarkFK20Backends(b *testing.B) {
+	_, _, _, _, ks := integrationTestSetup(8, 4242)
+
+	backends := map[string]func(*FFTSettings) ProofBackend{
+		"cpu": NewCPUBackend,
+	}
+
+	for _, cosetWidth := range benchScales {
+		for name, newBackend := range backends {
+			b.Run(fmt.Sprintf("%s/cosetWidth=%d", name, cosetWidth), func(b *testing.B) {
+				fk := NewFK20MultiSettingsWithBackend(ks, ks.maxWidth, cosetWidth, newBackend(ks.FFTSettings))
+				poly := make([]Big, ks.maxWidth)
+				for i := range poly {
+					CopyBigNum(&poly[i], randomBig())
+				}
+
+				b.ResetTimer()
+				start := time.Now()
+				proofs := 0
+				for i := 0; i < b.N; i++ {
+					proofs += len(fk.FK20MultiDAOptimized(poly))
+				}
+				b.ReportMetric(float64(proofs)/time.Since(start).Seconds(), "proofs/s")
+			})
+		}
+	}
+}
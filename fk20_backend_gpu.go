@@ -0,0 +1,29 @@
+// +build gpu
+
+package kzg
+
+// GPUBackend is a placeholder ProofBackend for GPU offload, built only with
+// the "gpu" build tag. It contains no device code and does no batching:
+// every method panics.
+type GPUBackend struct {
+	// batchSize is stored by NewGPUBackend; no method reads it.
+	batchSize int
+}
+
+// NewGPUBackend returns a GPUBackend holding batchSize. The returned
+// backend panics on every call.
+func NewGPUBackend(batchSize int) ProofBackend {
+	return &GPUBackend{batchSize: batchSize}
+}
+
+func (b *GPUBackend) MSM(points []G1, scalars []Big) G1 {
+	panic("kzg: GPUBackend.MSM needs a cgo/CUDA implementation behind the \"gpu\" build tag")
+}
+
+func (b *GPUBackend) FFTG1(vals []G1, inv bool) []G1 {
+	panic("kzg: GPUBackend.FFTG1 needs a cgo/CUDA implementation behind the \"gpu\" build tag")
+}
+
+func (b *GPUBackend) ToeplitzPart2(coeffsFFT []Big, row []G1) []G1 {
+	panic("kzg: GPUBackend.ToeplitzPart2 needs a cgo/CUDA implementation behind the \"gpu\" build tag")
+}
@@ -0,0 +1,286 @@
+package kzg
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+)
+
+// settingsMagic identifies a binary-encoded KZGSettings file, to fail fast
+// on a misidentified or truncated file rather than silently misinterpreting
+// its bytes. fk20SettingsMagic plays the same role for FK20MultiSettings:
+// the two layouts share a prefix (both start with a uint32 right after the
+// magic), so a distinct magic per type is what lets UnmarshalBinary reject
+// a file of the wrong kind instead of misreading its fields.
+const settingsMagic = "GOKZGS01"
+const fk20SettingsMagic = "GOKZGF01"
+
+// settingsVersion is bumped whenever the binary layout below changes in a
+// way that isn't backwards compatible.
+const settingsVersion = 1
+
+const (
+	compressedG1Size = 48
+	compressedG2Size = 96
+)
+
+// MarshalBinary writes ks out in a compact, length-prefixed binary layout:
+// a header (magic, version, maxWidth, a hash of the secretG1 points) followed
+// by the compressed secretG1 and secretG2 points. The expanded roots of
+// unity are not stored; UnmarshalBinary recomputes them from maxWidth.
+func (ks *KZGSettings) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(settingsMagic)
+	writeUint32(&buf, settingsVersion)
+	writeUint64(&buf, ks.maxWidth)
+
+	digest := hashG1Points(ks.secretG1)
+	buf.Write(digest[:])
+
+	writeUint64(&buf, uint64(len(ks.secretG1)))
+	for i := range ks.secretG1 {
+		c := CompressG1(&ks.secretG1[i])
+		buf.Write(c)
+	}
+	writeUint64(&buf, uint64(len(ks.secretG2)))
+	for i := range ks.secretG2 {
+		c := CompressG2(&ks.secretG2[i])
+		buf.Write(c)
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary loads settings written by MarshalBinary and rebuilds the
+// roots-of-unity FFT domain that NewKZGSettings would normally compute. If
+// checkHash is true, the decompressed secretG1 points are re-hashed and
+// compared against the header digest, catching bit-level corruption without
+// re-verifying subgroup membership of every point.
+func (ks *KZGSettings) UnmarshalBinary(data []byte, checkHash bool) error {
+	r := bytes.NewReader(data)
+
+	magic := make([]byte, len(settingsMagic))
+	if _, err := readFull(r, magic); err != nil {
+		return err
+	}
+	if string(magic) != settingsMagic {
+		return errors.New("kzg: not a KZGSettings binary file")
+	}
+	version, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	if version != settingsVersion {
+		return errors.New("kzg: unsupported KZGSettings binary version")
+	}
+	maxWidth, err := readUint64(r)
+	if err != nil {
+		return err
+	}
+
+	var wantDigest [sha256.Size]byte
+	if _, err := readFull(r, wantDigest[:]); err != nil {
+		return err
+	}
+
+	secretG1, err := readG1Slice(r)
+	if err != nil {
+		return err
+	}
+	secretG2, err := readG2Slice(r)
+	if err != nil {
+		return err
+	}
+	if checkHash {
+		if got := hashG1Points(secretG1); got != wantDigest {
+			return errors.New("kzg: secretG1 digest mismatch")
+		}
+	}
+
+	fs := NewFFTSettings(scaleOf(maxWidth))
+	ks.FFTSettings = fs
+	ks.secretG1 = secretG1
+	ks.secretG2 = secretG2
+	return nil
+}
+
+// MarshalBinary writes out fk's settings plus the precomputed Toeplitz
+// coefficient transform, so that FK20 multi-proof setup does not have to
+// redo its (relatively expensive) precomputation on every process start.
+func (fk *FK20MultiSettings) MarshalBinary() ([]byte, error) {
+	ksBytes, err := fk.KZGSettings.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(fk20SettingsMagic)
+	writeUint32(&buf, settingsVersion)
+	writeUint64(&buf, fk.chunkLen)
+	writeUint64(&buf, uint64(len(ksBytes)))
+	buf.Write(ksBytes)
+
+	writeUint64(&buf, uint64(len(fk.xExtFFTPrecomputes)))
+	for _, row := range fk.xExtFFTPrecomputes {
+		writeUint64(&buf, uint64(len(row)))
+		for i := range row {
+			buf.Write(CompressG1(&row[i]))
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary loads a file written by MarshalBinary and restores the
+// precomputed Toeplitz vectors alongside the underlying KZGSettings.
+func (fk *FK20MultiSettings) UnmarshalBinary(data []byte, checkHash bool) error {
+	r := bytes.NewReader(data)
+
+	magic := make([]byte, len(fk20SettingsMagic))
+	if _, err := readFull(r, magic); err != nil {
+		return err
+	}
+	if string(magic) != fk20SettingsMagic {
+		return errors.New("kzg: not an FK20MultiSettings binary file")
+	}
+	version, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	if version != settingsVersion {
+		return errors.New("kzg: unsupported FK20MultiSettings binary version")
+	}
+	chunkLen, err := readUint64(r)
+	if err != nil {
+		return err
+	}
+	ksLen, err := readUint64(r)
+	if err != nil {
+		return err
+	}
+	ksBytes := make([]byte, ksLen)
+	if _, err := readFull(r, ksBytes); err != nil {
+		return err
+	}
+
+	ks := &KZGSettings{}
+	if err := ks.UnmarshalBinary(ksBytes, checkHash); err != nil {
+		return err
+	}
+
+	rowCount, err := readUint64(r)
+	if err != nil {
+		return err
+	}
+	rows := make([][]G1, rowCount)
+	for i := range rows {
+		n, err := readUint64(r)
+		if err != nil {
+			return err
+		}
+		row, err := readG1SliceN(r, n)
+		if err != nil {
+			return err
+		}
+		rows[i] = row
+	}
+
+	fk.KZGSettings = ks
+	fk.chunkLen = chunkLen
+	fk.xExtFFTPrecomputes = rows
+	fk.backend = NewCPUBackend(ks.FFTSettings)
+	return nil
+}
+
+func hashG1Points(points []G1) [sha256.Size]byte {
+	h := sha256.New()
+	for i := range points {
+		h.Write(CompressG1(&points[i]))
+	}
+	var out [sha256.Size]byte
+	copy(out[:], h.Sum(nil))
+	return out
+}
+
+func scaleOf(width uint64) uint8 {
+	var scale uint8
+	for w := uint64(1); w < width; w <<= 1 {
+		scale++
+	}
+	return scale
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	buf.Write(b[:])
+}
+
+func writeUint64(buf *bytes.Buffer, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	buf.Write(b[:])
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	var b [4]byte
+	if _, err := readFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b[:]), nil
+}
+
+func readUint64(r *bytes.Reader) (uint64, error) {
+	var b [8]byte
+	if _, err := readFull(r, b[:]); err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint64(b[:]), nil
+}
+
+func readFull(r *bytes.Reader, b []byte) (int, error) {
+	n, err := r.Read(b)
+	if err == nil && n != len(b) {
+		err = errors.New("kzg: truncated binary settings file")
+	}
+	return n, err
+}
+
+func readG1Slice(r *bytes.Reader) ([]G1, error) {
+	n, err := readUint64(r)
+	if err != nil {
+		return nil, err
+	}
+	return readG1SliceN(r, n)
+}
+
+func readG1SliceN(r *bytes.Reader, n uint64) ([]G1, error) {
+	out := make([]G1, n)
+	c := make([]byte, compressedG1Size)
+	for i := range out {
+		if _, err := readFull(r, c); err != nil {
+			return nil, err
+		}
+		if err := DeserializeG1(&out[i], c); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func readG2Slice(r *bytes.Reader) ([]G2, error) {
+	n, err := readUint64(r)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]G2, n)
+	c := make([]byte, compressedG2Size)
+	for i := range out {
+		if _, err := readFull(r, c); err != nil {
+			return nil, err
+		}
+		if err := DeserializeG2(&out[i], c); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,134 @@
+package kzg
+
+import "errors"
+
+// evalPolyInEvalForm evaluates a polynomial, given in evaluation form with
+// evaluations[i] aligned with ks.expandedRootsOfUnity[i] in natural (not
+// bit-reversed) domain order, at an arbitrary point z. If z happens to
+// coincide with a domain point, the corresponding evaluation is returned
+// directly; otherwise the barycentric formula is used.
+func (ks *KZGSettings) evalPolyInEvalForm(evaluations []Big, z *Big) (*Big, error) {
+	if uint64(len(evaluations)) != ks.maxWidth {
+		return nil, errors.New("evaluations length does not match domain size")
+	}
+
+	var y Big
+	for i := range evaluations {
+		if equalBig(&ks.expandedRootsOfUnity[i], z) {
+			CopyBigNum(&y, &evaluations[i])
+			return &y, nil
+		}
+	}
+
+	// y = (z^width - 1) / width * sum_i evaluations[i] * domain[i] / (z - domain[i])
+	var sum Big
+	for i := range evaluations {
+		var num Big
+		mulModBig(&num, &evaluations[i], &ks.expandedRootsOfUnity[i])
+		var denom Big
+		subModBig(&denom, z, &ks.expandedRootsOfUnity[i])
+		var term Big
+		divModBig(&term, &num, &denom)
+		addModBig(&sum, &sum, &term)
+	}
+
+	var zPowWidth Big
+	CopyBigNum(&zPowWidth, z)
+	for w := uint64(1); w < ks.maxWidth; w <<= 1 {
+		mulModBig(&zPowWidth, &zPowWidth, &zPowWidth)
+	}
+	var zPowWidthMinusOne Big
+	subModBig(&zPowWidthMinusOne, &zPowWidth, &ONE)
+
+	var widthBig Big
+	asBig(&widthBig, ks.maxWidth)
+	var factor Big
+	divModBig(&factor, &zPowWidthMinusOne, &widthBig)
+
+	mulModBig(&y, &factor, &sum)
+	return &y, nil
+}
+
+// ComputeProof computes a KZG proof for the polynomial given in evaluation
+// form, with evaluations[i] aligned with ks.expandedRootsOfUnity[i] in
+// natural (not bit-reversed) domain order, opened at the point z. The proof
+// is a commitment to the quotient q(x) = (p(x) - p(z)) / (x - z), the same
+// construction as the EIP-4844 single-point proof; note that EIP-4844 blobs
+// are canonically bit-reversed, so a caller feeding this raw blob data must
+// first permute it into natural order (see FFTBitReversed).
+func (ks *KZGSettings) ComputeProof(evaluations []Big, z *Big) (*G1, error) {
+	if uint64(len(evaluations)) != ks.maxWidth {
+		return nil, errors.New("evaluations length does not match domain size")
+	}
+
+	y, err := ks.evalPolyInEvalForm(evaluations, z)
+	if err != nil {
+		return nil, err
+	}
+
+	zIndex := -1
+	for i := range evaluations {
+		if equalBig(&ks.expandedRootsOfUnity[i], z) {
+			zIndex = i
+			break
+		}
+	}
+
+	quotient := make([]Big, len(evaluations))
+	if zIndex == -1 {
+		// z is not in the domain: q(x) = (p(x) - y) / (x - z)
+		for i := range evaluations {
+			var num Big
+			subModBig(&num, &evaluations[i], y)
+			var denom Big
+			subModBig(&denom, &ks.expandedRootsOfUnity[i], z)
+			divModBig(&quotient[i], &num, &denom)
+		}
+	} else {
+		// z coincides with a domain point: the naive formula divides by
+		// zero at zIndex, so derive that term as the limit (L'Hopital)
+		// of the contributions of every other point instead.
+		for i := range evaluations {
+			if i == zIndex {
+				continue
+			}
+			var num Big
+			subModBig(&num, &evaluations[i], y)
+			var denom Big
+			subModBig(&denom, &ks.expandedRootsOfUnity[i], z)
+			divModBig(&quotient[i], &num, &denom)
+
+			var ratio Big
+			divModBig(&ratio, &ks.expandedRootsOfUnity[i], z)
+			var contribution Big
+			mulModBig(&contribution, &quotient[i], &ratio)
+			subModBig(&quotient[zIndex], &quotient[zIndex], &contribution)
+		}
+	}
+
+	// quotient is in evaluation form; ks.secretG1 is the monomial-basis
+	// SRS, so it must be committed in coefficient form.
+	coeffs, err := ks.FFT(quotient, true)
+	if err != nil {
+		return nil, err
+	}
+	return ks.CommitToPoly(coeffs), nil
+}
+
+// VerifyProof checks a single-point KZG opening: that commit is a commitment
+// to a polynomial p such that p(z) == y, given the opening proof produced by
+// ComputeProof. It checks the pairing equation
+// e(commit - [y]G1, G2) == e(proof, [s]G2 - [z]G2).
+func (ks *KZGSettings) VerifyProof(commit *G1, z *Big, y *Big, proof *G1) bool {
+	var yG1 G1
+	mulG1(&yG1, &GenG1, y)
+	var lhsG1 G1
+	subG1(&lhsG1, commit, &yG1)
+
+	var zG2 G2
+	mulG2(&zG2, &GenG2, z)
+	var rhsG2 G2
+	subG2(&rhsG2, &ks.secretG2[1], &zG2)
+
+	return PairingsVerify(&lhsG1, &GenG2, proof, &rhsG2)
+}
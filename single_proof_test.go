@@ -0,0 +1,59 @@
+// +build !bignum_pure,!bignum_hol256
+
+package kzg
+
+import "testing"
+
+func TestComputeVerifyProof(t *testing.T) {
+	_, _, extendedAsPoly, _, ks := integrationTestSetup(6, 5551)
+
+	evaluations, err := ks.FFT(extendedAsPoly, false)
+	if err != nil {
+		t.Fatalf("failed to get evaluation form: %v", err)
+	}
+	commit := ks.CommitToPoly(extendedAsPoly)
+
+	z := randomBig()
+	proof, err := ks.ComputeProof(evaluations, z)
+	if err != nil {
+		t.Fatalf("ComputeProof failed: %v", err)
+	}
+	y, err := ks.evalPolyInEvalForm(evaluations, z)
+	if err != nil {
+		t.Fatalf("evalPolyInEvalForm failed: %v", err)
+	}
+
+	if !ks.VerifyProof(commit, z, y, proof) {
+		t.Fatal("failed to verify a correctly computed proof")
+	}
+
+	var wrongY Big
+	addModBig(&wrongY, y, &ONE)
+	if ks.VerifyProof(commit, z, &wrongY, proof) {
+		t.Fatal("expected verification to fail for a wrong y")
+	}
+}
+
+func TestComputeVerifyProofAtDomainPoint(t *testing.T) {
+	_, _, extendedAsPoly, _, ks := integrationTestSetup(6, 777)
+
+	evaluations, err := ks.FFT(extendedAsPoly, false)
+	if err != nil {
+		t.Fatalf("failed to get evaluation form: %v", err)
+	}
+	commit := ks.CommitToPoly(extendedAsPoly)
+
+	z := ks.expandedRootsOfUnity[3]
+	proof, err := ks.ComputeProof(evaluations, &z)
+	if err != nil {
+		t.Fatalf("ComputeProof failed: %v", err)
+	}
+	y, err := ks.evalPolyInEvalForm(evaluations, &z)
+	if err != nil {
+		t.Fatalf("evalPolyInEvalForm failed: %v", err)
+	}
+
+	if !ks.VerifyProof(commit, &z, y, proof) {
+		t.Fatal("failed to verify a proof opened at a domain point")
+	}
+}